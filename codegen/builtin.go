@@ -0,0 +1,35 @@
+package codegen
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+var builtinNames = map[string]string{
+	"django":   "templates/django_migration.tmpl",
+	"rails":    "templates/rails_migration.tmpl",
+	"graphql":  "templates/graphql_schema.tmpl",
+	"protobuf": "templates/protobuf.tmpl",
+}
+
+func isBuiltin(name string) bool {
+	_, ok := builtinNames[name]
+	return ok
+}
+
+func readBuiltin(name string) (string, []byte, error) {
+	path, ok := builtinNames[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown built-in template %q", name)
+	}
+
+	content, err := builtinTemplates.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not read built-in template %s: %w", path, err)
+	}
+
+	return path, content, nil
+}
@@ -0,0 +1,102 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/breathbath/dslDictionaryImporter/table"
+)
+
+type RowData struct {
+	columns []string
+	values  table.Row
+}
+
+func (r RowData) Col(name string) string {
+	for i, col := range r.columns {
+		if col == name {
+			return r.values[i]
+		}
+	}
+
+	return ""
+}
+
+type TableData struct {
+	Name             string
+	Columns          []string
+	Rows             []RowData
+	UniqueColIndices []int
+}
+
+type Context struct {
+	Tables map[string]*TableData
+}
+
+func NewContext(tables []*table.Table) *Context {
+	ctx := &Context{Tables: make(map[string]*TableData, len(tables))}
+	for _, tbl := range tables {
+		rows := make([]RowData, 0, len(tbl.Rows()))
+		for _, row := range tbl.Rows() {
+			rows = append(rows, RowData{columns: tbl.Columns(), values: row})
+		}
+
+		ctx.Tables[tbl.Name()] = &TableData{
+			Name:             tbl.Name(),
+			Columns:          tbl.Columns(),
+			Rows:             rows,
+			UniqueColIndices: tbl.UniqueColIndices(),
+		}
+	}
+
+	return ctx
+}
+
+var funcMap = template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+}
+
+func Generate(tables []*table.Table, tmplPath, out string) error {
+	tmpl, err := parseTemplate(tmplPath)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("could not create %s: %w", out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := tmpl.Execute(w, NewContext(tables)); err != nil {
+		return fmt.Errorf("could not execute template %s: %w", tmplPath, err)
+	}
+
+	return nil
+}
+
+func parseTemplate(tmplPath string) (*template.Template, error) {
+	if isBuiltin(tmplPath) {
+		name, content, err := readBuiltin(tmplPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return template.New(filepath.Base(name)).Funcs(funcMap).Parse(string(content))
+	}
+
+	name := filepath.Base(tmplPath)
+	tmpl, err := template.New(name).Funcs(funcMap).ParseFiles(tmplPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template %s: %w", tmplPath, err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,67 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/breathbath/dslDictionaryImporter/table"
+)
+
+func TestGenerateWithCustomTemplate(t *testing.T) {
+	tbl := table.NewTable("words", []string{"word", "lang_id"})
+	tbl.AddRow("run", int64(1))
+	tbl.AddRow("walk", int64(1))
+
+	tmplPath := filepath.Join(t.TempDir(), "custom.tmpl")
+	tmplSrc := `{{range $name, $t := .Tables}}{{$name}}:{{range $t.Rows}} {{.Col "word"}}{{end}}
+{{end}}`
+	if err := os.WriteFile(tmplPath, []byte(tmplSrc), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "out.txt")
+	if err := Generate([]*table.Table{tbl}, tmplPath, outPath); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	out, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if got := string(out); got != "words: run walk\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestGenerateWithBuiltinTemplate(t *testing.T) {
+	tbl := table.NewTable("words", []string{"word"})
+	tbl.AddRow("run")
+
+	outPath := filepath.Join(t.TempDir(), "schema.graphql")
+	if err := Generate([]*table.Table{tbl}, "graphql", outPath); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	out, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if !strings.Contains(string(out), "words") {
+		t.Fatalf("got %q, want it to mention the table name", out)
+	}
+}
+
+func TestRowDataColReturnsEmptyForUnknownColumn(t *testing.T) {
+	row := RowData{columns: []string{"word"}, values: table.Row{"run"}}
+
+	if got := row.Col("missing"); got != "" {
+		t.Fatalf("got %q, want empty string for an unknown column", got)
+	}
+	if got := row.Col("word"); got != "run" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,44 @@
+package ast
+
+// Header carries the DSL file's directive values, e.g. #NAME, #INDEX_LANGUAGE.
+type Header struct {
+	Name             string
+	IndexLanguage    string
+	ContentsLanguage string
+}
+
+// Article is everything parsed for a single headword.
+type Article struct {
+	Headword string
+	Senses   []*Sense
+}
+
+// Sense groups one grammatical note with the translations and cross
+// references that follow it, at a given [m<N>] indentation level.
+type Sense struct {
+	IndentLevel  int
+	PartOfSpeech string
+	Translations []*Translation
+	Examples     []*Example
+	CrossRefs    []*CrossRef
+}
+
+// Translation is one [trn]...[/trn] entry, with any attributes ([i], [c
+// color]) captured alongside it.
+type Translation struct {
+	Text       string
+	Attributes []string
+}
+
+// Example is an [ex]...[/ex] usage example attached to a sense. Untagged
+// body text is not captured as an Example.
+type Example struct {
+	Text string
+}
+
+// CrossRef is a [*]...[/*] entry; IsRef marks a [ref] link to another
+// headword rather than a plain remark.
+type CrossRef struct {
+	Text  string
+	IsRef bool
+}
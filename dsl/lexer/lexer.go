@@ -0,0 +1,112 @@
+package lexer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headerPattern  = regexp.MustCompile(`^#(\w+)\s*"(.*)"`)
+	bracketPattern = regexp.MustCompile(`\[([^\]]*)]`)
+	indentTagMatch = regexp.MustCompile(`^m(\d+)$`)
+)
+
+// Lexer pulls tokens out of a DSL source one line at a time. Call Next
+// repeatedly until it returns a Token with Type == EOF.
+type Lexer struct {
+	scanner *bufio.Scanner
+	line    int
+	pending []Token
+}
+
+func New(r io.Reader) *Lexer {
+	return &Lexer{scanner: bufio.NewScanner(r)}
+}
+
+func (l *Lexer) Next() (Token, error) {
+	for len(l.pending) == 0 {
+		if !l.scanner.Scan() {
+			if err := l.scanner.Err(); err != nil {
+				return Token{}, fmt.Errorf("line %d: %w", l.line, err)
+			}
+			return Token{Type: EOF, Line: l.line}, nil
+		}
+		l.line++
+
+		line := l.scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		toks, err := l.tokenizeLine(line)
+		if err != nil {
+			return Token{}, err
+		}
+		l.pending = toks
+	}
+
+	tok := l.pending[0]
+	l.pending = l.pending[1:]
+	return tok, nil
+}
+
+func (l *Lexer) tokenizeLine(line string) ([]Token, error) {
+	if res := headerPattern.FindStringSubmatch(line); res != nil {
+		return []Token{{Type: HeaderDirective, Value: res[1], Param: res[2], Line: l.line, Col: 1}}, nil
+	}
+
+	if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "[") {
+		return []Token{{Type: HeadwordStart, Value: strings.TrimSpace(line), Line: l.line, Col: 1}}, nil
+	}
+
+	return l.tokenizeBody(line)
+}
+
+func (l *Lexer) tokenizeBody(line string) ([]Token, error) {
+	tokens := []Token{}
+	matches := bracketPattern.FindAllStringSubmatchIndex(line, -1)
+
+	cursor := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if text := line[cursor:start]; strings.TrimSpace(text) != "" {
+			tokens = append(tokens, Token{Type: Text, Value: text, Line: l.line, Col: cursor + 1})
+		}
+
+		content := line[m[2]:m[3]]
+		tok, err := l.tagToken(content, start+1)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+
+		cursor = end
+	}
+
+	if text := line[cursor:]; strings.TrimSpace(text) != "" {
+		tokens = append(tokens, Token{Type: Text, Value: text, Line: l.line, Col: cursor + 1})
+	}
+
+	return tokens, nil
+}
+
+func (l *Lexer) tagToken(content string, col int) (Token, error) {
+	if strings.HasPrefix(content, "/") {
+		return Token{Type: TagClose, Value: strings.TrimPrefix(content, "/"), Line: l.line, Col: col}, nil
+	}
+
+	name, attr, _ := strings.Cut(content, " ")
+	if res := indentTagMatch.FindStringSubmatch(name); res != nil {
+		level, err := strconv.Atoi(res[1])
+		if err != nil {
+			return Token{}, fmt.Errorf("line %d col %d: invalid indent level %q", l.line, col, name)
+		}
+		return Token{Type: IndentLevel, Value: strconv.Itoa(level), Line: l.line, Col: col}, nil
+	}
+
+	return Token{Type: TagOpen, Value: name, Param: strings.TrimSpace(attr), Line: l.line, Col: col}, nil
+}
@@ -0,0 +1,80 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+)
+
+func tokenize(t *testing.T, src string) []Token {
+	t.Helper()
+
+	l := New(strings.NewReader(src))
+	var toks []Token
+	for {
+		tok, err := l.Next()
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if tok.Type == EOF {
+			return toks
+		}
+		toks = append(toks, tok)
+	}
+}
+
+func TestTokenizeHeaderDirective(t *testing.T) {
+	toks := tokenize(t, `#NAME "Test Dictionary"`)
+	if len(toks) != 1 || toks[0].Type != HeaderDirective || toks[0].Value != "NAME" || toks[0].Param != "Test Dictionary" {
+		t.Fatalf("got %+v", toks)
+	}
+}
+
+func TestTokenizeHeadword(t *testing.T) {
+	toks := tokenize(t, "run")
+	if len(toks) != 1 || toks[0].Type != HeadwordStart || toks[0].Value != "run" {
+		t.Fatalf("got %+v", toks)
+	}
+}
+
+func TestTokenizeIndentedBodyIsNotAHeadword(t *testing.T) {
+	toks := tokenize(t, "    [p]v[/p]")
+	for _, tok := range toks {
+		if tok.Type == HeadwordStart {
+			t.Fatalf("got HeadwordStart from an indented body line: %+v", toks)
+		}
+	}
+}
+
+// A body line opening with an [mN] or other tag at column 0 (no leading
+// whitespace) must still be treated as body text, not a new headword.
+func TestTokenizeUnindentedTagLineIsNotAHeadword(t *testing.T) {
+	toks := tokenize(t, "[m1][p]v[/p][/m]")
+	for _, tok := range toks {
+		if tok.Type == HeadwordStart {
+			t.Fatalf("got HeadwordStart from a line starting with '[': %+v", toks)
+		}
+	}
+
+	if len(toks) == 0 {
+		t.Fatalf("expected body tokens, got none")
+	}
+	if toks[0].Type != IndentLevel || toks[0].Value != "1" {
+		t.Fatalf("got %+v, want a leading IndentLevel token", toks[0])
+	}
+}
+
+func TestTokenizeTagWithAttribute(t *testing.T) {
+	toks := tokenize(t, "    [c green]word[/c]")
+
+	var open Token
+	for _, tok := range toks {
+		if tok.Type == TagOpen {
+			open = tok
+			break
+		}
+	}
+
+	if open.Value != "c" || open.Param != "green" {
+		t.Fatalf("got %+v, want TagOpen c with Param green", open)
+	}
+}
@@ -0,0 +1,46 @@
+package lexer
+
+type Type int
+
+const (
+	EOF Type = iota
+	HeaderDirective
+	HeadwordStart
+	TagOpen
+	TagClose
+	Text
+	IndentLevel
+)
+
+func (t Type) String() string {
+	switch t {
+	case EOF:
+		return "EOF"
+	case HeaderDirective:
+		return "HeaderDirective"
+	case HeadwordStart:
+		return "HeadwordStart"
+	case TagOpen:
+		return "TagOpen"
+	case TagClose:
+		return "TagClose"
+	case Text:
+		return "Text"
+	case IndentLevel:
+		return "IndentLevel"
+	default:
+		return "Unknown"
+	}
+}
+
+// Token is a single lexical unit produced by the Lexer. Value carries the
+// directive/tag/text payload; Param carries a secondary value such as a
+// directive's quoted content or a tag's attribute string (e.g. "green" for
+// [c green]).
+type Token struct {
+	Type  Type
+	Value string
+	Param string
+	Line  int
+	Col   int
+}
@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/breathbath/dslDictionaryImporter/dsl/ast"
+	"github.com/breathbath/dslDictionaryImporter/dsl/lexer"
+)
+
+// ParseError carries the line/column of a malformed token so callers can
+// point the user at the offending DSL line.
+type ParseError struct {
+	Line, Col int
+	Msg       string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d col %d: %s", e.Line, e.Col, e.Msg)
+}
+
+// Parser is a pull parser: call Next repeatedly to get one *ast.Article per
+// headword until it returns io.EOF. Header directives seen along the way are
+// available through Header.
+type Parser struct {
+	lex       *lexer.Lexer
+	header    ast.Header
+	lookahead *lexer.Token
+}
+
+func New(r io.Reader) *Parser {
+	return &Parser{lex: lexer.New(r)}
+}
+
+func (p *Parser) Header() *ast.Header {
+	return &p.header
+}
+
+func (p *Parser) Next() (*ast.Article, error) {
+	tok, err := p.nextToken()
+	if err != nil {
+		return nil, err
+	}
+
+	for tok.Type == lexer.HeaderDirective {
+		p.applyHeader(tok)
+		tok, err = p.nextToken()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tok.Type == lexer.EOF {
+		return nil, io.EOF
+	}
+
+	if tok.Type != lexer.HeadwordStart {
+		return nil, &ParseError{Line: tok.Line, Col: tok.Col, Msg: fmt.Sprintf("expected a headword, got %s", tok.Type)}
+	}
+
+	article := &ast.Article{Headword: tok.Value}
+	if err := p.parseBody(article); err != nil {
+		return nil, err
+	}
+
+	return article, nil
+}
+
+func (p *Parser) applyHeader(tok lexer.Token) {
+	switch tok.Value {
+	case "NAME":
+		p.header.Name = tok.Param
+	case "INDEX_LANGUAGE":
+		p.header.IndexLanguage = tok.Param
+	case "CONTENTS_LANGUAGE":
+		p.header.ContentsLanguage = tok.Param
+	}
+}
+
+func (p *Parser) parseBody(article *ast.Article) error {
+	var current *ast.Sense
+	var textBuf strings.Builder
+	openTag := ""
+	color := ""
+	sawItalic := false
+	isRef := false
+
+	ensureSense := func() *ast.Sense {
+		if current == nil {
+			current = &ast.Sense{IndentLevel: 1}
+			article.Senses = append(article.Senses, current)
+		}
+		return current
+	}
+
+	for {
+		tok, err := p.nextToken()
+		if err != nil {
+			return err
+		}
+
+		switch tok.Type {
+		case lexer.EOF, lexer.HeadwordStart:
+			p.pushBack(tok)
+			return nil
+		case lexer.IndentLevel:
+			level, err := strconv.Atoi(tok.Value)
+			if err != nil {
+				return &ParseError{Line: tok.Line, Col: tok.Col, Msg: "invalid indent level"}
+			}
+			if current == nil || level <= 1 {
+				current = &ast.Sense{IndentLevel: level}
+				article.Senses = append(article.Senses, current)
+			}
+		case lexer.TagOpen:
+			switch tok.Value {
+			case "p", "trn", "*", "ex":
+				openTag = tok.Value
+				textBuf.Reset()
+				color = ""
+				sawItalic = false
+				if tok.Value == "*" {
+					isRef = false
+				}
+			case "i":
+				sawItalic = true
+			case "c":
+				color = tok.Param
+			case "ref":
+				isRef = true
+			}
+		case lexer.TagClose:
+			if tok.Value == openTag {
+				p.closeTag(ensureSense(), openTag, cleanup(textBuf.String()), color, sawItalic, isRef)
+				openTag = ""
+				continue
+			}
+		case lexer.Text:
+			if openTag != "" {
+				textBuf.WriteString(tok.Value)
+			}
+		}
+	}
+}
+
+func (p *Parser) closeTag(sense *ast.Sense, tag, text, color string, italic bool, isRef bool) {
+	switch tag {
+	case "p":
+		sense.PartOfSpeech = text
+	case "trn":
+		attrs := []string{}
+		if italic {
+			attrs = append(attrs, "italic")
+		}
+		if color != "" {
+			attrs = append(attrs, "color:"+color)
+		}
+		sense.Translations = append(sense.Translations, &ast.Translation{Text: text, Attributes: attrs})
+	case "ex":
+		sense.Examples = append(sense.Examples, &ast.Example{Text: text})
+	case "*":
+		sense.CrossRefs = append(sense.CrossRefs, &ast.CrossRef{Text: text, IsRef: isRef})
+	}
+}
+
+func (p *Parser) nextToken() (lexer.Token, error) {
+	if p.lookahead != nil {
+		tok := *p.lookahead
+		p.lookahead = nil
+		return tok, nil
+	}
+
+	return p.lex.Next()
+}
+
+func (p *Parser) pushBack(tok lexer.Token) {
+	p.lookahead = &tok
+}
+
+func cleanup(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseHeaderAndArticle(t *testing.T) {
+	src := `#NAME "Test Dictionary"
+#INDEX_LANGUAGE "English"
+#CONTENTS_LANGUAGE "Russian"
+run
+    [p]v[/p]
+    [trn]бежать[/trn]
+`
+	p := New(strings.NewReader(src))
+
+	article, err := p.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	if article.Headword != "run" {
+		t.Fatalf("got headword %q", article.Headword)
+	}
+	if h := p.Header(); h.Name != "Test Dictionary" || h.IndexLanguage != "English" || h.ContentsLanguage != "Russian" {
+		t.Fatalf("got header %+v", h)
+	}
+	if len(article.Senses) != 1 || article.Senses[0].PartOfSpeech != "v" {
+		t.Fatalf("got senses %+v", article.Senses)
+	}
+	if len(article.Senses[0].Translations) != 1 || article.Senses[0].Translations[0].Text != "бежать" {
+		t.Fatalf("got translations %+v", article.Senses[0].Translations)
+	}
+
+	if _, err := p.Next(); err != io.EOF {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+}
+
+// Formatting tags nested inside a leaf tag (the common [trn][i]...[/i][/trn]
+// shape) must still attach their attribute to the enclosing translation:
+// the inner [/i] closing must not erase it before [/trn] is seen.
+func TestParseNestedItalicSurvivesToEnclosingTag(t *testing.T) {
+	src := "run\n    [trn][i]to run[/i][/trn]\n"
+
+	p := New(strings.NewReader(src))
+	article, err := p.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	translations := article.Senses[0].Translations
+	if len(translations) != 1 {
+		t.Fatalf("got translations %+v", translations)
+	}
+	if translations[0].Text != "to run" {
+		t.Fatalf("got text %q", translations[0].Text)
+	}
+
+	found := false
+	for _, attr := range translations[0].Attributes {
+		if attr == "italic" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("got attributes %v, want \"italic\"", translations[0].Attributes)
+	}
+}
+
+func TestParseNestedColorSurvivesToEnclosingTag(t *testing.T) {
+	src := "run\n    [trn][c green]to run[/c][/trn]\n"
+
+	p := New(strings.NewReader(src))
+	article, err := p.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	translations := article.Senses[0].Translations
+	if len(translations) != 1 {
+		t.Fatalf("got translations %+v", translations)
+	}
+
+	found := false
+	for _, attr := range translations[0].Attributes {
+		if attr == "color:green" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("got attributes %v, want \"color:green\"", translations[0].Attributes)
+	}
+}
@@ -0,0 +1,196 @@
+// Package encoding sniffs the character encoding of a DSL file and wraps
+// it in a UTF-8 decoding reader, without depending on cgo.
+package encoding
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const peekSize = 4096
+
+const (
+	Auto    = "auto"
+	UTF8    = "utf-8"
+	UTF16LE = "utf-16le"
+	UTF16BE = "utf-16be"
+	Win1251 = "cp1251"
+	Win1252 = "cp1252"
+	Win1250 = "cp1250"
+)
+
+// NewReader peeks at the first 4 KiB of r to determine its encoding (when
+// want is Auto or empty) and returns a reader that yields UTF-8.
+func NewReader(r io.Reader, want string) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, peekSize)
+
+	name := want
+	if name == "" || name == Auto {
+		name = sniff(br)
+	}
+
+	if err := discardBOM(br, name); err != nil {
+		return nil, err
+	}
+
+	enc, err := byName(name)
+	if err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return br, nil
+	}
+
+	return transform.NewReader(br, enc.NewDecoder()), nil
+}
+
+// discardBOM consumes a byte order mark matching name off the front of br,
+// if one is actually present. byName builds its UTF-16 decoders with
+// IgnoreBOM, so a detected BOM is never implicitly consumed downstream;
+// without this it would decode into a stray U+FEFF ahead of the first
+// token.
+func discardBOM(br *bufio.Reader, name string) error {
+	var bom []byte
+	switch name {
+	case UTF8:
+		bom = []byte{0xEF, 0xBB, 0xBF}
+	case UTF16LE:
+		bom = []byte{0xFF, 0xFE}
+	case UTF16BE:
+		bom = []byte{0xFE, 0xFF}
+	default:
+		return nil
+	}
+
+	peek, err := br.Peek(len(bom))
+	if err != nil || !hasPrefix(peek, bom...) {
+		return nil
+	}
+
+	_, err = br.Discard(len(bom))
+	return err
+}
+
+func byName(name string) (encoding.Encoding, error) {
+	switch name {
+	case UTF8:
+		return nil, nil
+	case UTF16LE:
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), nil
+	case UTF16BE:
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), nil
+	case Win1251:
+		return charmap.Windows1251, nil
+	case Win1252:
+		return charmap.Windows1252, nil
+	case Win1250:
+		return charmap.Windows1250, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q", name)
+	}
+}
+
+func sniff(br *bufio.Reader) string {
+	peek, _ := br.Peek(peekSize)
+	if len(peek) == 0 {
+		return UTF8
+	}
+
+	switch {
+	case hasPrefix(peek, 0xEF, 0xBB, 0xBF):
+		return UTF8
+	case hasPrefix(peek, 0xFF, 0xFE):
+		return UTF16LE
+	case hasPrefix(peek, 0xFE, 0xFF):
+		return UTF16BE
+	case looksUTF16(peek, true):
+		return UTF16LE
+	case looksUTF16(peek, false):
+		return UTF16BE
+	case utf8.Valid(trimIncompleteRune(peek)):
+		return UTF8
+	case highBitDensity(peek):
+		return Win1251
+	default:
+		return UTF8
+	}
+}
+
+func hasPrefix(b []byte, prefix ...byte) bool {
+	if len(b) < len(prefix) {
+		return false
+	}
+	for i, p := range prefix {
+		if b[i] != p {
+			return false
+		}
+	}
+
+	return true
+}
+
+// looksUTF16 checks the null-byte stride of ASCII-heavy text encoded as
+// UTF-16 without a BOM: every other byte is 0x00.
+func looksUTF16(b []byte, little bool) bool {
+	n := len(b) - len(b)%2
+	if n < 8 {
+		return false
+	}
+
+	zeroOffset := 1
+	if !little {
+		zeroOffset = 0
+	}
+
+	zeros := 0
+	pairs := n / 2
+	for i := zeroOffset; i < n; i += 2 {
+		if b[i] == 0 {
+			zeros++
+		}
+	}
+
+	return float64(zeros)/float64(pairs) > 0.8
+}
+
+// trimIncompleteRune drops a trailing byte sequence that looks like the
+// start of a multi-byte UTF-8 rune cut short by peekSize, so a valid UTF-8
+// file isn't misdetected just because the truncation landed mid-rune.
+func trimIncompleteRune(b []byte) []byte {
+	for cut := 1; cut <= utf8.UTFMax-1 && cut <= len(b); cut++ {
+		start := len(b) - cut
+		if !utf8.RuneStart(b[start]) {
+			continue
+		}
+		if !utf8.FullRune(b[start:]) {
+			return b[:start]
+		}
+		return b
+	}
+
+	return b
+}
+
+// highBitDensity flags legacy single-byte encodings such as Windows-125x,
+// where a meaningful share of bytes has the high bit set.
+func highBitDensity(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+
+	highBit := 0
+	for _, c := range b {
+		if c >= 0x80 {
+			highBit++
+		}
+	}
+
+	return float64(highBit)/float64(len(b)) > 0.05
+}
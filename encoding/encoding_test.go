@@ -0,0 +1,115 @@
+package encoding
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func utf16LE(s string) []byte {
+	buf := make([]byte, 0, len(s)*2)
+	for _, r := range s {
+		buf = append(buf, byte(r), 0)
+	}
+	return buf
+}
+
+func utf16BE(s string) []byte {
+	buf := make([]byte, 0, len(s)*2)
+	for _, r := range s {
+		buf = append(buf, 0, byte(r))
+	}
+	return buf
+}
+
+func readAll(t *testing.T, r io.Reader) string {
+	t.Helper()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	return string(data)
+}
+
+func TestNewReaderStripsUTF8BOM(t *testing.T) {
+	input := append([]byte{0xEF, 0xBB, 0xBF}, []byte(`#NAME "Test"`)...)
+
+	r, err := NewReader(bytes.NewReader(input), Auto)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	if got := readAll(t, r); got != `#NAME "Test"` {
+		t.Fatalf("got %q, want BOM-free text", got)
+	}
+}
+
+func TestNewReaderStripsUTF16LEBOM(t *testing.T) {
+	input := append([]byte{0xFF, 0xFE}, utf16LE(`#NAME "Test"`)...)
+
+	r, err := NewReader(bytes.NewReader(input), Auto)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	if got := readAll(t, r); got != `#NAME "Test"` {
+		t.Fatalf("got %q, want BOM-free decoded text", got)
+	}
+}
+
+func TestNewReaderStripsUTF16BEBOM(t *testing.T) {
+	input := append([]byte{0xFE, 0xFF}, utf16BE(`#NAME "Test"`)...)
+
+	r, err := NewReader(bytes.NewReader(input), Auto)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	if got := readAll(t, r); got != `#NAME "Test"` {
+		t.Fatalf("got %q, want BOM-free decoded text", got)
+	}
+}
+
+func TestNewReaderHeuristicUTF16LEWithoutBOM(t *testing.T) {
+	input := utf16LE(`#NAME "Test"` + "\r\nsome more ascii text to pass the heuristic")
+
+	r, err := NewReader(bytes.NewReader(input), Auto)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	got := readAll(t, r)
+	if !bytes.HasPrefix([]byte(got), []byte(`#NAME "Test"`)) {
+		t.Fatalf("got %q, want it to start with the decoded header line", got)
+	}
+}
+
+// Non-ASCII UTF-8 text without a BOM has high-bit-set bytes on nearly every
+// character, same as a legacy single-byte encoding would; the UTF-8 check
+// must run before the highBitDensity fallback or this gets misdetected and
+// mangled as cp1251.
+func TestNewReaderDetectsBOMlessUTF8(t *testing.T) {
+	input := []byte(`#NAME "Тестовый словарь"`)
+
+	r, err := NewReader(bytes.NewReader(input), Auto)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	if got := readAll(t, r); got != `#NAME "Тестовый словарь"` {
+		t.Fatalf("got %q, want the UTF-8 text unchanged", got)
+	}
+}
+
+func TestNewReaderExplicitEncodingOverride(t *testing.T) {
+	input := []byte{0xC0, 0xE0} // "А" "а" in cp1251
+
+	r, err := NewReader(bytes.NewReader(input), Win1251)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	if got := readAll(t, r); got != "Аа" {
+		t.Fatalf("got %q, want cp1251-decoded text", got)
+	}
+}
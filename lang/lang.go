@@ -0,0 +1,113 @@
+// Package lang normalizes the human-readable language names DSL headers
+// carry in #INDEX_LANGUAGE / #CONTENTS_LANGUAGE into canonical BCP 47 tags.
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// builtin maps the DSL/Lingvo language names we've seen in the wild to a
+// BCP 47 tag. It is intentionally not exhaustive; unknown names can be
+// added via -lang-map.
+var builtin = map[string]string{
+	"english":    "en",
+	"russian":    "ru-RU",
+	"german":     "de",
+	"french":     "fr",
+	"spanish":    "es",
+	"italian":    "it",
+	"portuguese": "pt",
+	"dutch":      "nl",
+	"polish":     "pl",
+	"ukrainian":  "uk",
+	"chinese":    "zh",
+	"japanese":   "ja",
+	"korean":     "ko",
+	"arabic":     "ar",
+	"turkish":    "tr",
+	"swedish":    "sv",
+	"norwegian":  "nb",
+	"danish":     "da",
+	"finnish":    "fi",
+	"greek":      "el",
+	"czech":      "cs",
+	"hungarian":  "hu",
+	"romanian":   "ro",
+	"bulgarian":  "bg",
+	"vietnamese": "vi",
+	"thai":       "th",
+	"hebrew":     "he",
+}
+
+// Mapper normalizes DSL language names to BCP 47 tags.
+type Mapper struct {
+	mapping map[string]string
+	strict  bool
+}
+
+// NewMapper builds a Mapper from the built-in table plus an optional
+// overrides file (a JSON object of name -> BCP 47 tag). When strict is
+// true, Normalize fails on any name that is neither in the mapping (built
+// in or from overridesPath) nor a valid BCP 47 tag itself.
+func NewMapper(overridesPath string, strict bool) (*Mapper, error) {
+	mapping := make(map[string]string, len(builtin))
+	for name, tag := range builtin {
+		mapping[name] = tag
+	}
+
+	if overridesPath != "" {
+		overrides, err := loadOverrides(overridesPath)
+		if err != nil {
+			return nil, err
+		}
+		for name, tag := range overrides {
+			if _, err := language.Parse(tag); err != nil {
+				return nil, fmt.Errorf("%s: invalid BCP 47 tag %q for %q: %w", overridesPath, tag, name, err)
+			}
+			mapping[strings.ToLower(name)] = tag
+		}
+	}
+
+	return &Mapper{mapping: mapping, strict: strict}, nil
+}
+
+func loadOverrides(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read lang map %s: %w", path, err)
+	}
+
+	overrides := map[string]string{}
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("could not parse lang map %s: %w", path, err)
+	}
+
+	return overrides, nil
+}
+
+// Normalize returns the canonical BCP 47 tag for a language name. DSL
+// headers spell this out in full ("russian"); other sources such as XDXF
+// carry an ISO 639 code or other BCP 47 tag ("ru") directly, so a name not
+// found in the mapping is also tried as a tag in its own right before
+// falling back to strict's error. Otherwise it returns an empty tag.
+func (m *Mapper) Normalize(name string) (string, error) {
+	trimmed := strings.TrimSpace(name)
+
+	if tag, ok := m.mapping[strings.ToLower(trimmed)]; ok {
+		return tag, nil
+	}
+
+	if parsed, err := language.Parse(trimmed); err == nil {
+		return parsed.String(), nil
+	}
+
+	if m.strict {
+		return "", fmt.Errorf("unrecognized language %q, add it via -lang-map or pass -lang-strict=false", name)
+	}
+	return "", nil
+}
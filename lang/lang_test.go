@@ -0,0 +1,46 @@
+package lang
+
+import "testing"
+
+func TestNormalizeFullName(t *testing.T) {
+	m, err := NewMapper("", true)
+	if err != nil {
+		t.Fatalf("NewMapper: %v", err)
+	}
+
+	tag, err := m.Normalize("Russian")
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if tag != "ru-RU" {
+		t.Fatalf("got %q", tag)
+	}
+}
+
+// XDXF and similar sources carry ISO codes rather than full names; those
+// should resolve even though they aren't in the builtin table.
+func TestNormalizeFallsBackToBCP47Tag(t *testing.T) {
+	m, err := NewMapper("", true)
+	if err != nil {
+		t.Fatalf("NewMapper: %v", err)
+	}
+
+	tag, err := m.Normalize("en")
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if tag != "en" {
+		t.Fatalf("got %q", tag)
+	}
+}
+
+func TestNormalizeUnrecognizedStrict(t *testing.T) {
+	m, err := NewMapper("", true)
+	if err != nil {
+		t.Fatalf("NewMapper: %v", err)
+	}
+
+	if _, err := m.Normalize("not a language"); err == nil {
+		t.Fatalf("expected an error for an unrecognized, non-BCP-47 name")
+	}
+}
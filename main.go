@@ -1,140 +1,44 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
 	"flag"
 	"fmt"
+	"github.com/breathbath/dslDictionaryImporter/codegen"
+	"github.com/breathbath/dslDictionaryImporter/dsl/ast"
+	"github.com/breathbath/dslDictionaryImporter/encoding"
+	"github.com/breathbath/dslDictionaryImporter/lang"
+	"github.com/breathbath/dslDictionaryImporter/output"
+	"github.com/breathbath/dslDictionaryImporter/sources"
+	"github.com/breathbath/dslDictionaryImporter/table"
 	"github.com/breathbath/go_utils/utils/errs"
 	"github.com/breathbath/go_utils/utils/fs"
-	"github.com/djimenez/iconv-go"
-	"github.com/olekukonko/tablewriter"
+	"golang.org/x/text/language"
 	"log"
 	"os"
-	"regexp"
-	"strconv"
-	"strings"
 )
 
-type Row []string
-
-type Table struct {
-	name             string
-	counter          int64
-	rows             []Row
-	columns          []string
-	uniqueColIndices []int
-	uniqueValues     map[string]int64
-}
-
-func NewTable(name string, columns []string) *Table {
-	return &Table{
-		name:             name,
-		counter:          0,
-		columns:          columns,
-		rows:             []Row{},
-		uniqueColIndices: []int{},
-		uniqueValues:     make(map[string]int64),
-	}
-}
-
-func (t *Table) SetUniqueCols(uniqueCols ...string) {
-	for _, uniqueCol := range uniqueCols {
-		found := false
-		for kCol, col := range t.columns {
-			if col == uniqueCol {
-				found = true
-				t.uniqueColIndices = append(t.uniqueColIndices, kCol)
-				break
-			}
-		}
-		if !found {
-			log.Panicf("Unknown unique column %s", uniqueCol)
-		}
-	}
-}
-
-func (t *Table) AddRow(row ...interface{}) int64 {
-	counter := t.counter + 1
-
-	rowColsCount := len(row)
-	tableRowsCount := len(t.columns)
-	if rowColsCount != tableRowsCount {
-		log.Panicf("Columns count %d in a row does not correspond to the table columns count %d", rowColsCount, tableRowsCount)
-	}
-
-	concatUniqueValue := ""
-	if len(t.uniqueColIndices) > 0 {
-		for _, uniqueColIndex := range t.uniqueColIndices {
-			concatUniqueValue += fmt.Sprint(row[uniqueColIndex])
-		}
-		if rowId, ok := t.uniqueValues[concatUniqueValue]; ok {
-			return rowId
-		}
-		t.uniqueValues[concatUniqueValue] = counter
-	}
-
-	rowStrs := []string{}
-	for _, rowVal := range row {
-		rowStrs = append(rowStrs, fmt.Sprint(rowVal))
-	}
-
-	t.rows = append(t.rows, rowStrs)
-	t.counter = counter
-
-	return counter
-}
-
-func (t *Table) Change(val string, col, row int64) {
-	if int(row) > len(t.rows)-1 {
-		log.Panicf("Row %d is out of range", row)
-	}
-
-	if int(col) > len(t.columns)-1 {
-		log.Panicf("Column %d is out of range", col)
-	}
-
-	t.rows[row][col] = val
-}
-
-func (t *Table) String() string {
-	buf := new(bytes.Buffer)
-	outputTable := tablewriter.NewWriter(buf)
-	columns := append([]string{"Id"}, t.columns...)
-	outputTable.SetCaption(true, t.name)
-	outputTable.SetHeader(columns)
-	for k, row := range t.rows {
-		row := append([]string{fmt.Sprint(k + 1)}, row...)
-		outputTable.Append(row)
-	}
-
-	outputTable.Render()
-
-	return buf.String()
-}
-
 func main() {
-	tables := []*Table{}
-	wordsTable := NewTable("words", []string{"word", "dic_id", "lang_id"})
+	tables := []*table.Table{}
+	wordsTable := table.NewTable("words", []string{"word", "dic_id", "lang_id"})
 
-	dictionariesTable := NewTable("dictionaries", []string{"name"})
+	dictionariesTable := table.NewTable("dictionaries", []string{"name"})
 
-	translationsTable := NewTable("translations", []string{"word_from_id", "word_to_id"})
+	translationsTable := table.NewTable("translations", []string{"word_from_id", "word_to_id"})
 	translationsTable.SetUniqueCols("word_from_id", "word_to_id")
 
-	gramTypeTable := NewTable("gramTypes", []string{"value"})
+	gramTypeTable := table.NewTable("gramTypes", []string{"value"})
 	gramTypeTable.SetUniqueCols("value")
 
-	gramTypeTranslationTable := NewTable("gramTypesToTranslations", []string{"gram_type_id", "translation_id"})
+	gramTypeTranslationTable := table.NewTable("gramTypesToTranslations", []string{"gram_type_id", "translation_id"})
 	gramTypeTranslationTable.SetUniqueCols("gram_type_id", "translation_id")
 
-	translationAttributesTable := NewTable("translationAttributes", []string{"value"})
+	translationAttributesTable := table.NewTable("translationAttributes", []string{"value"})
 	translationAttributesTable.SetUniqueCols("value")
 
-	translationAttributesToTranslationsTable := NewTable("translationAttributesTranslations", []string{"attribute_id", "translation_id"})
+	translationAttributesToTranslationsTable := table.NewTable("translationAttributesTranslations", []string{"attribute_id", "translation_id"})
 	translationAttributesToTranslationsTable.SetUniqueCols("attribute_id", "translation_id")
 
-	languagesTable := NewTable("languages", []string{"name"})
+	languagesTable := table.NewTable("languages", []string{"name", "bcp47"})
 	languagesTable.SetUniqueCols("name")
 
 	tables = append(
@@ -148,7 +52,15 @@ func main() {
 		translationAttributesTable,
 	)
 
-	path := flag.String("path", "", "/tmp/my.dsl")
+	path := flag.String("path", "", "source file (or, for -source stardict, its .ifo file or containing directory)")
+	format := flag.String("format", "", "sql|json|csv|sqlite, defaults to printing tables to stdout")
+	out := flag.String("out", "", "output file or directory, depending on -format")
+	dialect := flag.String("dialect", "", "SQL dialect for -format sql: mysql|postgres|sqlite, defaults to mysql")
+	tmplPath := flag.String("template", "", "path to a text/template file, or one of django|rails|graphql|protobuf")
+	langMap := flag.String("lang-map", "", "JSON file of DSL language name -> BCP 47 tag overrides")
+	langStrict := flag.Bool("lang-strict", true, "fail when a DSL language name has no known BCP 47 tag")
+	encodingName := flag.String("encoding", encoding.Auto, "auto|utf-8|utf-16le|utf-16be|cp1251|cp1252|cp1250")
+	sourceFormat := flag.String("source", sources.DSL, "dsl|stardict|xdxf|tsv")
 	flag.Parse()
 	if *path == "" {
 		log.Panic("Path is not provided")
@@ -163,176 +75,137 @@ func main() {
 
 	defer file.Close()
 
-	reader, err := iconv.NewReader(file, "utf-16", "utf-8")
+	reader, err := encoding.NewReader(file, *encodingName)
 	errs.FailOnError(err)
 
-	scanner := bufio.NewScanner(reader)
-	var dicId, langFromId, langToId, wordFromId, gramTypeId, attributeId, translationId, wordToId int64
-	var dicName, langFromName, langToName string
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		if dicName == "" && scanTitle("#NAME", line, &dicName) {
-			dicId = dictionariesTable.AddRow(dicName)
-			continue
-		}
-
-		if langFromName == "" && scanTitle("#INDEX_LANGUAGE", line, &langFromName) {
-			langFromId = languagesTable.AddRow(langFromName)
-			continue
-		}
-
-		if langToName == "" && scanTitle("#CONTENTS_LANGUAGE", line, &langToName) {
-			langToId = languagesTable.AddRow(langToName)
-			continue
-		}
+	langMapper, err := lang.NewMapper(*langMap, *langStrict)
+	errs.FailOnError(err)
 
-		wordArticleTitle := scanWordArticleTitle(line)
-		if wordArticleTitle != "" {
-			wordFromId = wordsTable.AddRow(wordArticleTitle, dicId, langFromId)
-			gramTypeId, attributeId = 0, 0
-			continue
-		}
-		err := validateBodyLine(line)
-		errs.FailOnError(err)
+	langTags := map[int64]language.Tag{}
+	wordsTable.SetCollatedUniqueCol("word", "lang_id", func(langID int64) language.Tag {
+		return langTags[langID]
+	})
 
-		_, note := extractNote(line)
-		translation := extractTranslation(line)
-		if note != "" && translation == "" {
-			gramTypeId = gramTypeTable.AddRow(note)
-			continue
-		}
+	src, err := sources.New(*sourceFormat, *path)
+	errs.FailOnError(err)
 
-		if translation != "" {
-			wordToId = wordsTable.AddRow(translation, dicId, langToId)
-			translationId = translationsTable.AddRow(wordFromId, wordToId)
-			if note != ""{
-				attributeId = translationAttributesTable.AddRow(note)
-				translationAttributesToTranslationsTable.AddRow(attributeId, translationId)
-				attributeId = 0
-			}
-			if gramTypeId > 0 {
-				gramTypeTranslationTable.AddRow(gramTypeId, translationId)
-			}
-			continue
-		}
+	sink := &tableSink{
+		dictionariesTable:                        dictionariesTable,
+		languagesTable:                            languagesTable,
+		wordsTable:                                wordsTable,
+		translationsTable:                         translationsTable,
+		gramTypeTable:                             gramTypeTable,
+		gramTypeTranslationTable:                  gramTypeTranslationTable,
+		translationAttributesTable:                translationAttributesTable,
+		translationAttributesToTranslationsTable:  translationAttributesToTranslationsTable,
+		langMapper:                                langMapper,
+		langTags:                                  langTags,
 	}
-	for _, tbl := range tables {
-		fmt.Println(tbl)
-	}
-}
 
-func validateBodyLine(inputLine string) error {
-	pattern := regexp.MustCompile(`^\s+`)
-	if !pattern.MatchString(inputLine) {
-		return fmt.Errorf("Line is not beginning with spaces")
-	}
+	err = src.Parse(reader, sink)
+	errs.FailOnError(err)
 
-	expectedTags := []string{"[p]", "[trn]", "[*]"}
-	for _, expectedTag := range expectedTags {
-		if strings.Contains(inputLine, expectedTag) {
-			return nil
-		}
+	wordsTable.SortByCollation("lang_id", "word")
+
+	if *tmplPath != "" {
+		err := codegen.Generate(tables, *tmplPath, *out)
+		errs.FailOnError(err)
+		return
 	}
-	return fmt.Errorf(
-		"Line '%s' is not containing one of expected tags: %s",
-		inputLine,
-		strings.Join(expectedTags, ","),
-	)
-}
 
-func extractIndex(inputLine string) int64 {
-	pattern := regexp.MustCompile(`^\s*\[.*?](\d*)[)|.]`)
-	res := pattern.FindStringSubmatch(inputLine)
-	if res != nil {
-		inputLineInt, err := strconv.ParseInt(res[1], 10, 64)
-		if err != nil {
-			return 0
+	if *format == "" {
+		for _, tbl := range tables {
+			fmt.Println(tbl)
 		}
-		return inputLineInt
+		return
 	}
 
-	return 0
-}
-func extractTranslation(inputLine string) string {
-	pattern := regexp.MustCompile(`\[trn](.*)\[/trn]`)
-	res := pattern.FindStringSubmatch(inputLine)
-	if res != nil {
-		return cleanupLine(res[1])
-	}
+	writer, err := output.New(*format, *out, *dialect)
+	errs.FailOnError(err)
 
-	return ""
+	err = writer.Write(tables)
+	errs.FailOnError(err)
 }
 
-func extractTranslationAttributes(inputLine string) (color string, isItalic bool) {
-	pattern := regexp.MustCompile(`.*\[/.*?](.*)\[trn]`)
-	res := pattern.FindStringSubmatch(inputLine)
-	if res == nil {
-		return "", false
-	}
-
-	isItalic = strings.Contains(res[1], "[i]")
-
-	pattern = regexp.MustCompile(`\[c (.*?)]`)
-	res = pattern.FindStringSubmatch(res[1])
+// tableSink adapts the Table layer to sources.ArticleSink, so any
+// sources.Source can populate the same tables the DSL importer always has.
+type tableSink struct {
+	dictionariesTable                        *table.Table
+	languagesTable                           *table.Table
+	wordsTable                                *table.Table
+	translationsTable                         *table.Table
+	gramTypeTable                             *table.Table
+	gramTypeTranslationTable                  *table.Table
+	translationAttributesTable                *table.Table
+	translationAttributesToTranslationsTable  *table.Table
+	langMapper                                *lang.Mapper
+	langTags                                  map[int64]language.Tag
 
-	if res != nil {
-		color = res[1]
-	}
-
-	return
+	dicId, langFromId, langToId int64
 }
 
-func extractRelation(inputLine string) (string, string) {
-	pattern := regexp.MustCompile(`\[\*](.*)?\[/\*]`)
-	res := pattern.FindStringSubmatch(inputLine)
-	if res == nil {
-		return "", ""
+func (s *tableSink) Header(h *ast.Header) {
+	if s.dicId == 0 && h.Name != "" {
+		s.dicId = s.dictionariesTable.AddRow(h.Name)
 	}
-
-	if strings.Contains(res[1], "[ref]") {
-		return "", cleanupLine(res[1])
+	if s.langFromId == 0 && h.IndexLanguage != "" {
+		s.langFromId = addLanguage(s.languagesTable, s.langMapper, s.langTags, h.IndexLanguage)
 	}
+	if s.langToId == 0 && h.ContentsLanguage != "" {
+		s.langToId = addLanguage(s.languagesTable, s.langMapper, s.langTags, h.ContentsLanguage)
+	}
+}
 
-	return cleanupLine(res[1]), ""
+func (s *tableSink) Article(a *ast.Article) error {
+	addArticle(
+		a,
+		s.dicId, s.langFromId, s.langToId,
+		s.wordsTable, s.translationsTable, s.gramTypeTable, s.gramTypeTranslationTable,
+		s.translationAttributesTable, s.translationAttributesToTranslationsTable,
+	)
+	return nil
 }
 
-func extractNote(inputLine string) (string, string) {
-	pattern := regexp.MustCompile(`\[p.*?](\[c\s*(.*?)])?(.*?)\[/`)
-	res := pattern.FindStringSubmatch(inputLine)
-	if res != nil {
-		return res[2], cleanupLine(res[3])
+func addLanguage(languagesTable *table.Table, langMapper *lang.Mapper, langTags map[int64]language.Tag, name string) int64 {
+	tag, err := langMapper.Normalize(name)
+	errs.FailOnError(err)
+
+	id := languagesTable.AddRow(name, tag)
+	if _, ok := langTags[id]; !ok {
+		parsedTag, err := language.Parse(tag)
+		if err == nil {
+			langTags[id] = parsedTag
+		}
 	}
 
-	return "", ""
+	return id
 }
 
-func cleanupLine(inputLine string) string {
-	pattern := regexp.MustCompile(`\[.*?]`)
-	result := pattern.ReplaceAllString(inputLine, "")
+func addArticle(
+	article *ast.Article,
+	dicId, langFromId, langToId int64,
+	wordsTable, translationsTable, gramTypeTable, gramTypeTranslationTable, translationAttributesTable, translationAttributesToTranslationsTable *table.Table,
+) {
+	wordFromId := wordsTable.AddRow(article.Headword, dicId, langFromId)
 
-	pattern = regexp.MustCompile(`\s{2,}`)
-	result = pattern.ReplaceAllString(result, " ")
+	for _, sense := range article.Senses {
+		var gramTypeId int64
+		if sense.PartOfSpeech != "" {
+			gramTypeId = gramTypeTable.AddRow(sense.PartOfSpeech)
+		}
 
-	return strings.TrimSpace(result)
-}
+		for _, translation := range sense.Translations {
+			wordToId := wordsTable.AddRow(translation.Text, dicId, langToId)
+			translationId := translationsTable.AddRow(wordFromId, wordToId)
 
-func scanWordArticleTitle(inputLine string) string {
-	pattern := regexp.MustCompile(`^[^\s\[].*`)
-	return pattern.FindString(inputLine)
-}
+			for _, attr := range translation.Attributes {
+				attributeId := translationAttributesTable.AddRow(attr)
+				translationAttributesToTranslationsTable.AddRow(attributeId, translationId)
+			}
 
-func scanTitle(prefix, inputLine string, dest *string) bool {
-	regexStr := fmt.Sprintf(`%s\s*"(.*)"`, prefix)
-	pattern := regexp.MustCompile(regexStr)
-	res := pattern.FindStringSubmatch(inputLine)
-	if res != nil {
-		*dest = res[1]
-		return true
+			if gramTypeId > 0 {
+				gramTypeTranslationTable.AddRow(gramTypeId, translationId)
+			}
+		}
 	}
-
-	return false
 }
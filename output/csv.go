@@ -0,0 +1,57 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/breathbath/dslDictionaryImporter/table"
+)
+
+type CSVWriter struct {
+	Out string
+}
+
+func (w *CSVWriter) Write(tables []*table.Table) error {
+	if w.Out == "" {
+		return fmt.Errorf("CSVWriter requires an output directory, use -out")
+	}
+
+	if err := os.MkdirAll(w.Out, 0755); err != nil {
+		return fmt.Errorf("could not create output directory %s: %w", w.Out, err)
+	}
+
+	for _, tbl := range tables {
+		if err := w.writeTable(tbl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *CSVWriter) writeTable(tbl *table.Table) error {
+	path := filepath.Join(w.Out, tbl.Name()+".csv")
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	writer := csv.NewWriter(f)
+	if err := writer.Write(append([]string{"id"}, tbl.Columns()...)); err != nil {
+		return err
+	}
+
+	ids := tbl.IDs()
+	for k, row := range tbl.Rows() {
+		record := append([]string{fmt.Sprint(ids[k])}, row...)
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/breathbath/dslDictionaryImporter/table"
+)
+
+type JSONWriter struct {
+	Out string
+}
+
+func (w *JSONWriter) Write(tables []*table.Table) error {
+	result := make(map[string][]map[string]string, len(tables))
+	for _, tbl := range tables {
+		result[tbl.Name()] = rowsToMaps(tbl)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal tables to json: %w", err)
+	}
+
+	if w.Out == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	return os.WriteFile(w.Out, data, 0644)
+}
+
+func rowsToMaps(tbl *table.Table) []map[string]string {
+	columns := tbl.Columns()
+	rows := tbl.Rows()
+	result := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		rowMap := make(map[string]string, len(columns))
+		for i, col := range columns {
+			rowMap[col] = row[i]
+		}
+		result = append(result, rowMap)
+	}
+
+	return result
+}
@@ -0,0 +1,146 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/breathbath/dslDictionaryImporter/table"
+)
+
+type Dialect string
+
+const (
+	DialectMySQL    Dialect = "mysql"
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+func ParseDialect(dialect string) (Dialect, error) {
+	if dialect == "" {
+		return DialectMySQL, nil
+	}
+
+	switch Dialect(dialect) {
+	case DialectMySQL, DialectPostgres, DialectSQLite:
+		return Dialect(dialect), nil
+	default:
+		return "", fmt.Errorf("unknown SQL dialect %q", dialect)
+	}
+}
+
+type SQLWriter struct {
+	Out     string
+	Dialect Dialect
+}
+
+func (w *SQLWriter) Write(tables []*table.Table) error {
+	buf := new(bytes.Buffer)
+	for _, tbl := range tables {
+		w.writeCreateTable(buf, tbl)
+		w.writeInserts(buf, tbl)
+	}
+
+	return w.flush(buf)
+}
+
+func (w *SQLWriter) writeCreateTable(buf *bytes.Buffer, tbl *table.Table) {
+	fmt.Fprintf(buf, "CREATE TABLE %s (\n", w.quoteIdent(tbl.Name()))
+	fmt.Fprintf(buf, "    %s %s,\n", w.quoteIdent("id"), w.idColumnType())
+	for _, col := range tbl.Columns() {
+		fmt.Fprintf(buf, "    %s TEXT,\n", w.quoteIdent(col))
+	}
+
+	if unique := w.uniqueConstraint(tbl); unique != "" {
+		fmt.Fprintf(buf, "    %s\n", unique)
+	} else {
+		buf.Truncate(buf.Len() - 2)
+		buf.WriteString("\n")
+	}
+	buf.WriteString(");\n\n")
+}
+
+func (w *SQLWriter) uniqueConstraint(tbl *table.Table) string {
+	indices := tbl.UniqueColIndices()
+	if len(indices) == 0 {
+		return ""
+	}
+
+	cols := tbl.Columns()
+	quoted := make([]string, 0, len(indices))
+	for _, idx := range indices {
+		quoted = append(quoted, w.quoteIdent(cols[idx]))
+	}
+
+	return fmt.Sprintf("UNIQUE (%s)", strings.Join(quoted, ", "))
+}
+
+func (w *SQLWriter) writeInserts(buf *bytes.Buffer, tbl *table.Table) {
+	columns := append([]string{"id"}, tbl.Columns()...)
+	quotedCols := make([]string, len(columns))
+	for i, col := range columns {
+		quotedCols[i] = w.quoteIdent(col)
+	}
+
+	ids := tbl.IDs()
+	for k, row := range tbl.Rows() {
+		values := make([]string, 0, len(row)+1)
+		values = append(values, fmt.Sprint(ids[k]))
+		for _, val := range row {
+			values = append(values, w.quoteValue(val))
+		}
+		fmt.Fprintf(
+			buf,
+			"INSERT INTO %s (%s) VALUES (%s);\n",
+			w.quoteIdent(tbl.Name()),
+			strings.Join(quotedCols, ", "),
+			strings.Join(values, ", "),
+		)
+	}
+	buf.WriteString("\n")
+}
+
+func (w *SQLWriter) idColumnType() string {
+	switch w.Dialect {
+	case DialectPostgres:
+		return "SERIAL PRIMARY KEY"
+	case DialectSQLite:
+		return "INTEGER PRIMARY KEY"
+	default:
+		return "INTEGER PRIMARY KEY AUTO_INCREMENT"
+	}
+}
+
+func (w *SQLWriter) quoteIdent(ident string) string {
+	switch w.Dialect {
+	case DialectPostgres, DialectSQLite:
+		return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+	default:
+		return "`" + strings.ReplaceAll(ident, "`", "``") + "`"
+	}
+}
+
+func (w *SQLWriter) quoteValue(val string) string {
+	if w.Dialect == DialectMySQL {
+		val = strings.ReplaceAll(val, `\`, `\\`)
+	}
+
+	return "'" + strings.ReplaceAll(val, "'", "''") + "'"
+}
+
+func (w *SQLWriter) flush(buf *bytes.Buffer) error {
+	var out io.Writer = os.Stdout
+	if w.Out != "" {
+		f, err := os.Create(w.Out)
+		if err != nil {
+			return fmt.Errorf("could not create %s: %w", w.Out, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	_, err := out.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,87 @@
+package output
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/breathbath/dslDictionaryImporter/table"
+)
+
+func writeSQL(t *testing.T, dialect Dialect, tbl *table.Table) string {
+	t.Helper()
+
+	f, err := os.CreateTemp(t.TempDir(), "out-*.sql")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	f.Close()
+
+	w := &SQLWriter{Out: f.Name(), Dialect: dialect}
+	if err := w.Write([]*table.Table{tbl}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	return string(data)
+}
+
+// A value ending in a backslash must not be able to escape its closing
+// quote under MySQL, where backslash is a string-literal escape character.
+func TestQuoteValueEscapesBackslashForMySQL(t *testing.T) {
+	tbl := table.NewTable("words", []string{"word"})
+	tbl.AddRow(`evil\`)
+
+	out := writeSQL(t, DialectMySQL, tbl)
+
+	if !strings.Contains(out, `'evil\\'`) {
+		t.Fatalf("got %q, want the trailing backslash doubled", out)
+	}
+
+	// Simulate what MySQL itself would parse: an escaped backslash followed
+	// by the real terminating quote, not an escaped quote.
+	if strings.Contains(out, `'evil\',`) {
+		t.Fatalf("got %q, value still ends in an unescaped backslash before the quote", out)
+	}
+}
+
+func TestQuoteValueDoublesSingleQuotes(t *testing.T) {
+	tbl := table.NewTable("words", []string{"word"})
+	tbl.AddRow(`it's`)
+
+	out := writeSQL(t, DialectMySQL, tbl)
+
+	if !strings.Contains(out, `'it''s'`) {
+		t.Fatalf("got %q, want the single quote doubled", out)
+	}
+}
+
+// Postgres/SQLite standard string literals don't treat backslash as an
+// escape character, so it must be left alone there.
+func TestQuoteValueLeavesBackslashForPostgresAndSQLite(t *testing.T) {
+	for _, dialect := range []Dialect{DialectPostgres, DialectSQLite} {
+		tbl := table.NewTable("words", []string{"word"})
+		tbl.AddRow(`evil\`)
+
+		out := writeSQL(t, dialect, tbl)
+
+		if !strings.Contains(out, `'evil\'`) {
+			t.Fatalf("[%s] got %q, want the backslash left untouched", dialect, out)
+		}
+	}
+}
+
+func TestWriteCreateTableIncludesIDColumn(t *testing.T) {
+	tbl := table.NewTable("words", []string{"word"})
+	tbl.AddRow("run")
+
+	out := writeSQL(t, DialectMySQL, tbl)
+
+	if !strings.Contains(out, "CREATE TABLE `words`") || !strings.Contains(out, "`id`") {
+		t.Fatalf("got %q, want a CREATE TABLE with an id column", out)
+	}
+}
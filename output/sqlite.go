@@ -0,0 +1,111 @@
+package output
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/breathbath/dslDictionaryImporter/table"
+	_ "modernc.org/sqlite"
+)
+
+type SQLiteWriter struct {
+	Out string
+}
+
+func (w *SQLiteWriter) Write(tables []*table.Table) error {
+	if w.Out == "" {
+		return fmt.Errorf("SQLiteWriter requires a target file, use -out")
+	}
+
+	if err := os.Remove(w.Out); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove existing %s: %w", w.Out, err)
+	}
+
+	db, err := sql.Open("sqlite", w.Out)
+	if err != nil {
+		return fmt.Errorf("could not open %s: %w", w.Out, err)
+	}
+	defer db.Close()
+
+	for _, tbl := range tables {
+		if err := w.writeTable(db, tbl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *SQLiteWriter) writeTable(db *sql.DB, tbl *table.Table) error {
+	if _, err := db.Exec(w.createTableSQL(tbl)); err != nil {
+		return fmt.Errorf("could not create table %s: %w", tbl.Name(), err)
+	}
+
+	columns := append([]string{"id"}, tbl.Columns()...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+	insertSQL := fmt.Sprintf(
+		`INSERT INTO "%s" (%s) VALUES (%s)`,
+		tbl.Name(),
+		quoteColumns(columns),
+		placeholders,
+	)
+
+	stmt, err := db.Prepare(insertSQL)
+	if err != nil {
+		return fmt.Errorf("could not prepare insert for %s: %w", tbl.Name(), err)
+	}
+	defer stmt.Close()
+
+	ids := tbl.IDs()
+	for k, row := range tbl.Rows() {
+		args := make([]interface{}, 0, len(row)+1)
+		args = append(args, ids[k])
+		for _, val := range row {
+			args = append(args, val)
+		}
+		if _, err := stmt.Exec(args...); err != nil {
+			return fmt.Errorf("could not insert row into %s: %w", tbl.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func (w *SQLiteWriter) createTableSQL(tbl *table.Table) string {
+	columnDefs := []string{`"id" INTEGER PRIMARY KEY`}
+	for _, col := range tbl.Columns() {
+		columnDefs = append(columnDefs, fmt.Sprintf(`"%s" TEXT`, col))
+	}
+
+	if unique := w.uniqueConstraint(tbl); unique != "" {
+		columnDefs = append(columnDefs, unique)
+	}
+
+	return fmt.Sprintf(`CREATE TABLE "%s" (%s)`, tbl.Name(), strings.Join(columnDefs, ", "))
+}
+
+func (w *SQLiteWriter) uniqueConstraint(tbl *table.Table) string {
+	indices := tbl.UniqueColIndices()
+	if len(indices) == 0 {
+		return ""
+	}
+
+	cols := tbl.Columns()
+	quoted := make([]string, 0, len(indices))
+	for _, idx := range indices {
+		quoted = append(quoted, fmt.Sprintf(`"%s"`, cols[idx]))
+	}
+
+	return fmt.Sprintf("UNIQUE (%s)", strings.Join(quoted, ", "))
+}
+
+func quoteColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = fmt.Sprintf(`"%s"`, col)
+	}
+
+	return strings.Join(quoted, ", ")
+}
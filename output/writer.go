@@ -0,0 +1,37 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/breathbath/dslDictionaryImporter/table"
+)
+
+type Writer interface {
+	Write(tables []*table.Table) error
+}
+
+const (
+	FormatSQL    = "sql"
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+	FormatSQLite = "sqlite"
+)
+
+func New(format, out, dialect string) (Writer, error) {
+	switch format {
+	case FormatSQL:
+		d, err := ParseDialect(dialect)
+		if err != nil {
+			return nil, err
+		}
+		return &SQLWriter{Out: out, Dialect: d}, nil
+	case FormatJSON:
+		return &JSONWriter{Out: out}, nil
+	case FormatCSV:
+		return &CSVWriter{Out: out}, nil
+	case FormatSQLite:
+		return &SQLiteWriter{Out: out}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,37 @@
+package sources
+
+import (
+	"io"
+
+	"github.com/breathbath/dslDictionaryImporter/dsl/parser"
+)
+
+// DSLSource parses Lingvo DSL dictionaries via dsl/lexer and dsl/parser.
+type DSLSource struct{}
+
+func (s *DSLSource) Parse(r io.Reader, sink ArticleSink) error {
+	p := parser.New(r)
+	headerSent := false
+
+	for {
+		article, err := p.Next()
+		if err == io.EOF {
+			if !headerSent {
+				sink.Header(p.Header())
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !headerSent {
+			sink.Header(p.Header())
+			headerSent = true
+		}
+
+		if err := sink.Article(article); err != nil {
+			return err
+		}
+	}
+}
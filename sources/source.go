@@ -0,0 +1,49 @@
+// Package sources parses bilingual lexicons of various formats into a
+// stream of *ast.Article values, so the Table layer stays the common
+// backbone for any of them, not just Lingvo DSL.
+package sources
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/breathbath/dslDictionaryImporter/dsl/ast"
+)
+
+// ArticleSink receives a Source's dictionary-level header once, then one
+// Article call per headword.
+type ArticleSink interface {
+	Header(h *ast.Header)
+	Article(a *ast.Article) error
+}
+
+// Source parses a single dictionary format. Most implementations read r
+// directly; StarDict instead reads the .idx/.dict files resolved at
+// construction time and ignores r.
+type Source interface {
+	Parse(r io.Reader, sink ArticleSink) error
+}
+
+const (
+	DSL      = "dsl"
+	StarDict = "stardict"
+	XDXF     = "xdxf"
+	TSV      = "tsv"
+)
+
+// New builds the Source for format. path is only used by StarDict, to
+// resolve the .ifo/.idx/.dict triple.
+func New(format, path string) (Source, error) {
+	switch format {
+	case DSL, "":
+		return &DSLSource{}, nil
+	case StarDict:
+		return NewStarDictSource(path)
+	case XDXF:
+		return &XDXFSource{}, nil
+	case TSV:
+		return &TSVSource{}, nil
+	default:
+		return nil, fmt.Errorf("unknown source format %q", format)
+	}
+}
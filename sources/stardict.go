@@ -0,0 +1,119 @@
+package sources
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/breathbath/dslDictionaryImporter/dsl/ast"
+)
+
+// StarDictSource reads a .ifo+.idx+.dict triple sharing a basename. It
+// ignores the r passed to Parse and reads its own files instead, since a
+// StarDict dictionary is never a single stream.
+type StarDictSource struct {
+	ifoPath  string
+	idxPath  string
+	dictPath string
+}
+
+// NewStarDictSource resolves the .ifo/.idx/.dict files for a StarDict
+// dictionary. path may point at the .ifo file itself, at the shared
+// basename without extension, or at a directory containing the triple.
+func NewStarDictSource(path string) (*StarDictSource, error) {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		matches, err := filepath.Glob(filepath.Join(path, "*.ifo"))
+		if err != nil || len(matches) == 0 {
+			return nil, fmt.Errorf("no .ifo file found in %s", path)
+		}
+		base = strings.TrimSuffix(matches[0], ".ifo")
+	}
+
+	return &StarDictSource{
+		ifoPath:  base + ".ifo",
+		idxPath:  base + ".idx",
+		dictPath: base + ".dict",
+	}, nil
+}
+
+func (s *StarDictSource) Parse(_ io.Reader, sink ArticleSink) error {
+	header, err := s.readHeader()
+	if err != nil {
+		return err
+	}
+	sink.Header(header)
+
+	idx, err := os.Open(s.idxPath)
+	if err != nil {
+		return fmt.Errorf("could not open %s: %w", s.idxPath, err)
+	}
+	defer idx.Close()
+
+	dict, err := os.ReadFile(s.dictPath)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", s.dictPath, err)
+	}
+
+	reader := bufio.NewReader(idx)
+	for {
+		word, err := reader.ReadString(0)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", s.idxPath, err)
+		}
+		word = strings.TrimSuffix(word, "\x00")
+
+		var offset, size uint32
+		if err := binary.Read(reader, binary.BigEndian, &offset); err != nil {
+			return fmt.Errorf("could not read offset for %q: %w", word, err)
+		}
+		if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+			return fmt.Errorf("could not read size for %q: %w", word, err)
+		}
+
+		if uint64(offset)+uint64(size) > uint64(len(dict)) {
+			return fmt.Errorf("article for %q points outside %s", word, s.dictPath)
+		}
+
+		article := &ast.Article{
+			Headword: word,
+			Senses: []*ast.Sense{{
+				Translations: []*ast.Translation{{Text: string(dict[offset : offset+size])}},
+			}},
+		}
+
+		if err := sink.Article(article); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *StarDictSource) readHeader() (*ast.Header, error) {
+	f, err := os.Open(s.ifoPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s: %w", s.ifoPath, err)
+	}
+	defer f.Close()
+
+	header := &ast.Header{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+		if key == "bookname" {
+			header.Name = value
+		}
+	}
+
+	return header, scanner.Err()
+}
@@ -0,0 +1,79 @@
+package sources
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/breathbath/dslDictionaryImporter/dsl/ast"
+)
+
+type recordingSink struct {
+	header   *ast.Header
+	articles []*ast.Article
+}
+
+func (s *recordingSink) Header(h *ast.Header)        { s.header = h }
+func (s *recordingSink) Article(a *ast.Article) error { s.articles = append(s.articles, a); return nil }
+
+func writeStarDictFixture(t *testing.T, offset, size uint32) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "dict")
+
+	if err := os.WriteFile(base+".ifo", []byte("bookname=Test Dictionary\n"), 0644); err != nil {
+		t.Fatalf("write .ifo: %v", err)
+	}
+
+	idx := []byte("run\x00")
+	idx = binary.BigEndian.AppendUint32(idx, offset)
+	idx = binary.BigEndian.AppendUint32(idx, size)
+	if err := os.WriteFile(base+".idx", idx, 0644); err != nil {
+		t.Fatalf("write .idx: %v", err)
+	}
+
+	if err := os.WriteFile(base+".dict", []byte("to run"), 0644); err != nil {
+		t.Fatalf("write .dict: %v", err)
+	}
+
+	return base + ".ifo"
+}
+
+func TestStarDictParsesValidEntry(t *testing.T) {
+	path := writeStarDictFixture(t, 0, 6)
+
+	src, err := NewStarDictSource(path)
+	if err != nil {
+		t.Fatalf("NewStarDictSource: %v", err)
+	}
+
+	sink := &recordingSink{}
+	if err := src.Parse(nil, sink); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(sink.articles) != 1 || sink.articles[0].Headword != "run" {
+		t.Fatalf("got articles %+v", sink.articles)
+	}
+	if got := sink.articles[0].Senses[0].Translations[0].Text; got != "to run" {
+		t.Fatalf("got translation %q", got)
+	}
+}
+
+// offset+size wrapping around uint32's range must not defeat the bounds
+// check and then panic on the slice expression.
+func TestStarDictRejectsOverflowingOffsetSize(t *testing.T) {
+	path := writeStarDictFixture(t, 4, 0xFFFFFFFF)
+
+	src, err := NewStarDictSource(path)
+	if err != nil {
+		t.Fatalf("NewStarDictSource: %v", err)
+	}
+
+	sink := &recordingSink{}
+	if err := src.Parse(nil, sink); err == nil {
+		t.Fatalf("expected an error for an out-of-range offset/size, got nil")
+	}
+}
@@ -0,0 +1,52 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/breathbath/dslDictionaryImporter/dsl/ast"
+)
+
+// TSVSource parses simple tab-separated glossaries: one
+// headword<TAB>translation line per entry, with an optional third
+// part-of-speech column.
+type TSVSource struct{}
+
+func (s *TSVSource) Parse(r io.Reader, sink ArticleSink) error {
+	sink.Header(&ast.Header{})
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) < 2 {
+			return fmt.Errorf("line %d: expected headword<TAB>translation, got %q", line, text)
+		}
+
+		sense := &ast.Sense{
+			Translations: []*ast.Translation{{Text: strings.TrimSpace(fields[1])}},
+		}
+		if len(fields) >= 3 {
+			sense.PartOfSpeech = strings.TrimSpace(fields[2])
+		}
+
+		article := &ast.Article{
+			Headword: strings.TrimSpace(fields[0]),
+			Senses:   []*ast.Sense{sense},
+		}
+
+		if err := sink.Article(article); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,36 @@
+package sources
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTSVParsesHeadwordTranslationAndPOS(t *testing.T) {
+	src := &TSVSource{}
+	sink := &recordingSink{}
+
+	input := "run\tto run\tv\nwalk\tто walk\n"
+	if err := src.Parse(strings.NewReader(input), sink); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(sink.articles) != 2 {
+		t.Fatalf("got %d articles, want 2", len(sink.articles))
+	}
+
+	if sink.articles[0].Headword != "run" || sink.articles[0].Senses[0].PartOfSpeech != "v" {
+		t.Fatalf("got %+v", sink.articles[0])
+	}
+	if sink.articles[1].Headword != "walk" || sink.articles[1].Senses[0].PartOfSpeech != "" {
+		t.Fatalf("got %+v", sink.articles[1])
+	}
+}
+
+func TestTSVRejectsMissingTranslation(t *testing.T) {
+	src := &TSVSource{}
+	sink := &recordingSink{}
+
+	if err := src.Parse(strings.NewReader("run\n"), sink); err == nil {
+		t.Fatalf("expected an error for a line with no translation column")
+	}
+}
@@ -0,0 +1,61 @@
+package sources
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/breathbath/dslDictionaryImporter/dsl/ast"
+)
+
+type xdxfDoc struct {
+	XMLName  xml.Name      `xml:"xdxf"`
+	FullName string        `xml:"full_name"`
+	From     string        `xml:"languages>from"`
+	To       string        `xml:"languages>to"`
+	Articles []xdxfArticle `xml:"ar"`
+}
+
+type xdxfArticle struct {
+	Headword     string   `xml:"k"`
+	PartOfSpeech string   `xml:"pos"`
+	Translations []string `xml:"tr"`
+}
+
+// XDXFSource parses the XML-based Dictionary eXchange Format, against the
+// <xdxf>/<ar> schema.
+type XDXFSource struct{}
+
+func (s *XDXFSource) Parse(r io.Reader, sink ArticleSink) error {
+	var doc xdxfDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return fmt.Errorf("could not decode xdxf document: %w", err)
+	}
+
+	sink.Header(&ast.Header{
+		Name:             doc.FullName,
+		IndexLanguage:    doc.From,
+		ContentsLanguage: doc.To,
+	})
+
+	for _, a := range doc.Articles {
+		translations := make([]*ast.Translation, 0, len(a.Translations))
+		for _, t := range a.Translations {
+			translations = append(translations, &ast.Translation{Text: t})
+		}
+
+		article := &ast.Article{
+			Headword: a.Headword,
+			Senses: []*ast.Sense{{
+				PartOfSpeech: a.PartOfSpeech,
+				Translations: translations,
+			}},
+		}
+
+		if err := sink.Article(article); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package sources
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestXDXFParsesHeaderAndArticles(t *testing.T) {
+	input := `<xdxf><full_name>Test Dictionary</full_name><languages><from>en</from><to>ru</to></languages>
+<ar><k>run</k><pos>v</pos><tr>бежать</tr><tr>бегать</tr></ar>
+</xdxf>`
+
+	src := &XDXFSource{}
+	sink := &recordingSink{}
+	if err := src.Parse(strings.NewReader(input), sink); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if sink.header.Name != "Test Dictionary" || sink.header.IndexLanguage != "en" || sink.header.ContentsLanguage != "ru" {
+		t.Fatalf("got header %+v", sink.header)
+	}
+
+	if len(sink.articles) != 1 {
+		t.Fatalf("got %d articles, want 1", len(sink.articles))
+	}
+
+	article := sink.articles[0]
+	if article.Headword != "run" || article.Senses[0].PartOfSpeech != "v" {
+		t.Fatalf("got %+v", article)
+	}
+	if len(article.Senses[0].Translations) != 2 || article.Senses[0].Translations[0].Text != "бежать" {
+		t.Fatalf("got translations %+v", article.Senses[0].Translations)
+	}
+}
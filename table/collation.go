@@ -0,0 +1,106 @@
+package table
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// LangResolver maps a lang_id foreign key, as stored on a row (e.g. the
+// lang_id column on wordsTable), to the language.Tag it was imported with.
+type LangResolver func(langID int64) language.Tag
+
+type collation struct {
+	wordColIndex int
+	langColIndex int
+	resolve      LangResolver
+	collators    map[string]*collate.Collator
+}
+
+func (c *collation) collatorFor(tag language.Tag) *collate.Collator {
+	key := tag.String()
+	if col, ok := c.collators[key]; ok {
+		return col
+	}
+
+	col := collate.New(tag)
+	c.collators[key] = col
+
+	return col
+}
+
+func (c *collation) dedupKey(row []interface{}) string {
+	langID, _ := row[c.langColIndex].(int64)
+	word := fmt.Sprint(row[c.wordColIndex])
+	tag := c.resolve(langID)
+
+	buf := &collate.Buffer{}
+	key := c.collatorFor(tag).KeyFromString(buf, word)
+
+	return strconv.FormatInt(langID, 10) + ":" + string(key)
+}
+
+// SetCollatedUniqueCol makes AddRow treat wordCol as unique within each
+// lang_id, comparing its values locale-aware via golang.org/x/text/collate
+// instead of raw string concatenation, using the language.Tag resolve
+// returns for the lang_id carried in langCol on the same row. wordCol is
+// not added to UniqueColIndices.
+func (t *Table) SetCollatedUniqueCol(wordCol, langCol string, resolve LangResolver) {
+	t.collation = &collation{
+		wordColIndex: t.mustColIndex(wordCol),
+		langColIndex: t.mustColIndex(langCol),
+		resolve:      resolve,
+		collators:    make(map[string]*collate.Collator),
+	}
+}
+
+// SortByCollation reorders the table's rows the way a dictionary reader
+// would expect for each row's language, grouping by language first. It
+// requires SetCollatedUniqueCol to have been called on this table, so a
+// LangResolver is available.
+//
+// Rows carry their id (as assigned by AddRow) alongside them in t.ids, kept
+// in lockstep with t.rows here, so foreign keys other tables captured
+// before this sort still point at the right row afterwards.
+func (t *Table) SortByCollation(langCol, wordCol string) {
+	if t.collation == nil {
+		log.Panicf("table %s has no collation configured, call SetCollatedUniqueCol first", t.name)
+	}
+
+	langColIndex := t.mustColIndex(langCol)
+	wordColIndex := t.mustColIndex(wordCol)
+
+	order := make([]int, len(t.rows))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+
+		langIDI, _ := strconv.ParseInt(t.rows[i][langColIndex], 10, 64)
+		langIDJ, _ := strconv.ParseInt(t.rows[j][langColIndex], 10, 64)
+
+		tagI := t.collation.resolve(langIDI)
+		tagJ := t.collation.resolve(langIDJ)
+
+		if tagI.String() != tagJ.String() {
+			return tagI.String() < tagJ.String()
+		}
+
+		return t.collation.collatorFor(tagI).CompareString(t.rows[i][wordColIndex], t.rows[j][wordColIndex]) < 0
+	})
+
+	sortedRows := make([]Row, len(t.rows))
+	sortedIDs := make([]int64, len(t.ids))
+	for newPos, oldPos := range order {
+		sortedRows[newPos] = t.rows[oldPos]
+		sortedIDs[newPos] = t.ids[oldPos]
+	}
+	t.rows = sortedRows
+	t.ids = sortedIDs
+}
@@ -0,0 +1,47 @@
+package table
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// SortByCollation must not change which id a row reports: other tables
+// capture the id AddRow returns as a foreign key, and that reference has to
+// keep pointing at the same logical row after this table is reordered.
+func TestSortByCollationPreservesRowIDs(t *testing.T) {
+	tbl := NewTable("words", []string{"word", "lang_id"})
+
+	tags := map[int64]language.Tag{1: language.English}
+	tbl.SetCollatedUniqueCol("word", "lang_id", func(langID int64) language.Tag {
+		return tags[langID]
+	})
+
+	zebraID := tbl.AddRow("zebra", int64(1))
+	appleID := tbl.AddRow("apple", int64(1))
+	mangoID := tbl.AddRow("mango", int64(1))
+
+	tbl.SortByCollation("lang_id", "word")
+
+	wantOrder := map[int64]string{zebraID: "zebra", appleID: "apple", mangoID: "mango"}
+
+	ids := tbl.IDs()
+	rows := tbl.Rows()
+	if len(ids) != len(rows) {
+		t.Fatalf("got %d ids for %d rows", len(ids), len(rows))
+	}
+
+	for i, id := range ids {
+		if got, want := rows[i][0], wantOrder[id]; got != want {
+			t.Fatalf("row at position %d has id %d and word %q, want word %q for that id", i, id, got, want)
+		}
+	}
+
+	gotWords := []string{rows[0][0], rows[1][0], rows[2][0]}
+	wantWords := []string{"apple", "mango", "zebra"}
+	for i := range wantWords {
+		if gotWords[i] != wantWords[i] {
+			t.Fatalf("got word order %v, want %v", gotWords, wantWords)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type Row []string
+
+type Table struct {
+	name             string
+	counter          int64
+	rows             []Row
+	ids              []int64
+	columns          []string
+	uniqueColIndices []int
+	uniqueValues     map[string]int64
+	collation        *collation
+}
+
+func NewTable(name string, columns []string) *Table {
+	return &Table{
+		name:             name,
+		counter:          0,
+		columns:          columns,
+		rows:             []Row{},
+		ids:              []int64{},
+		uniqueColIndices: []int{},
+		uniqueValues:     make(map[string]int64),
+	}
+}
+
+func (t *Table) SetUniqueCols(uniqueCols ...string) {
+	for _, uniqueCol := range uniqueCols {
+		t.uniqueColIndices = append(t.uniqueColIndices, t.mustColIndex(uniqueCol))
+	}
+}
+
+func (t *Table) mustColIndex(col string) int {
+	for kCol, c := range t.columns {
+		if c == col {
+			return kCol
+		}
+	}
+
+	log.Panicf("Unknown column %s", col)
+	return -1
+}
+
+func (t *Table) AddRow(row ...interface{}) int64 {
+	counter := t.counter + 1
+
+	rowColsCount := len(row)
+	tableRowsCount := len(t.columns)
+	if rowColsCount != tableRowsCount {
+		log.Panicf("Columns count %d in a row does not correspond to the table columns count %d", rowColsCount, tableRowsCount)
+	}
+
+	if t.collation != nil {
+		uniqueValue := t.collation.dedupKey(row)
+		if rowId, ok := t.uniqueValues[uniqueValue]; ok {
+			return rowId
+		}
+		t.uniqueValues[uniqueValue] = counter
+	} else if len(t.uniqueColIndices) > 0 {
+		concatUniqueValue := ""
+		for _, uniqueColIndex := range t.uniqueColIndices {
+			concatUniqueValue += fmt.Sprint(row[uniqueColIndex])
+		}
+		if rowId, ok := t.uniqueValues[concatUniqueValue]; ok {
+			return rowId
+		}
+		t.uniqueValues[concatUniqueValue] = counter
+	}
+
+	rowStrs := []string{}
+	for _, rowVal := range row {
+		rowStrs = append(rowStrs, fmt.Sprint(rowVal))
+	}
+
+	t.rows = append(t.rows, rowStrs)
+	t.ids = append(t.ids, counter)
+	t.counter = counter
+
+	return counter
+}
+
+func (t *Table) Change(val string, col, row int64) {
+	if int(row) > len(t.rows)-1 {
+		log.Panicf("Row %d is out of range", row)
+	}
+
+	if int(col) > len(t.columns)-1 {
+		log.Panicf("Column %d is out of range", col)
+	}
+
+	t.rows[row][col] = val
+}
+
+func (t *Table) Name() string {
+	return t.name
+}
+
+func (t *Table) Columns() []string {
+	return t.columns
+}
+
+func (t *Table) Rows() []Row {
+	return t.rows
+}
+
+func (t *Table) UniqueColIndices() []int {
+	return t.uniqueColIndices
+}
+
+// IDs returns the id assigned to each row in Rows(), by position. Unlike a
+// row's position in Rows(), an id is stable across SortByCollation: it's
+// the value AddRow returned when the row was first inserted, so foreign
+// keys captured by other tables stay valid after this table is reordered.
+func (t *Table) IDs() []int64 {
+	return t.ids
+}
+
+func (t *Table) String() string {
+	buf := new(bytes.Buffer)
+	outputTable := tablewriter.NewWriter(buf)
+	columns := append([]string{"Id"}, t.columns...)
+	outputTable.SetCaption(true, t.name)
+	outputTable.SetHeader(columns)
+	for k, row := range t.rows {
+		row := append([]string{fmt.Sprint(t.ids[k])}, row...)
+		outputTable.Append(row)
+	}
+
+	outputTable.Render()
+
+	return buf.String()
+}